@@ -3,37 +3,580 @@ package injector
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // Injector is a function that extracts a value from the request.
 type Injector func(*http.Request) any
 
-var injectors = map[reflect.Type]Injector{}
+// Scope is a hierarchical container of resolvers. Resolution checks the
+// Scope itself first, then walks up through SetParent before giving up,
+// so a short-lived request Scope can fall through to a long-lived root
+// Scope for singletons (loggers, repositories, config) while overriding
+// or adding resolvers that only make sense for that one request (the
+// request itself, path params, an authenticated user, ...).
+type Scope struct {
+	mu        sync.RWMutex
+	resolvers map[reflect.Type]Injector
+	parent    *Scope
+}
 
-// RegisterResolver registers a function that resolves a type dynamically per request.
-func RegisterResolver[T any](fn func(*http.Request) T) {
-	var zero T
-	t := reflect.TypeOf(zero)
-	if _, exists := injectors[t]; exists {
+// New creates an empty Scope with no parent.
+func New() *Scope {
+	return &Scope{resolvers: map[reflect.Type]Injector{}}
+}
+
+// SetParent sets the Scope to fall back to when a type isn't registered
+// locally. Get/resolve walks child -> parent until a resolver is found.
+func (s *Scope) SetParent(parent *Scope) {
+	s.parent = parent
+}
+
+// Child creates a new Scope with this Scope set as its parent.
+func (s *Scope) Child() *Scope {
+	child := New()
+	child.SetParent(s)
+	return child
+}
+
+// register adds a resolver for t, panicking if one is already registered
+// directly on this Scope (shadowing a parent's resolver is fine), or if t
+// is nil — a caller that instantiated RegisterResolverOn/RegisterStaticOn
+// with an interface type rather than RegisterResolverAsOn/RegisterStaticAsOn
+// would otherwise silently register under a nil map key, permanently
+// unreachable from Inject/Resolve.
+func (s *Scope) register(t reflect.Type, fn Injector) {
+	if t == nil {
+		panic("injector: cannot register a resolver for a nil type; use RegisterResolverAsOn/RegisterStaticAsOn (or RegisterResolverAs/RegisterStaticAs) to register against an interface")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.resolvers[t]; exists {
 		panic("injector already registered for type: " + t.String())
 	}
+	s.resolvers[t] = fn
+}
 
-	injectors[t] = func(r *http.Request) any {
-		return fn(r)
+// set adds or overwrites a resolver for t, used to seed per-request scopes
+// with values that always exist, like the request and response writer.
+func (s *Scope) set(t reflect.Type, fn Injector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resolvers[t] = fn
+}
+
+// resolveExact looks for a resolver registered under exactly t, walking
+// the parent chain.
+func (s *Scope) resolveExact(t reflect.Type) (Injector, bool) {
+	s.mu.RLock()
+	fn, ok := s.resolvers[t]
+	s.mu.RUnlock()
+	if ok {
+		return fn, true
+	}
+	if s.parent != nil {
+		return s.parent.resolveExact(t)
 	}
+	return nil, false
 }
 
-// RegisterStatic is a convenience helper to register static instances.
-func RegisterStatic[T any](val T) {
-	RegisterResolver(func(_ *http.Request) T {
+// resolveAssignable scans this Scope and its parents for a resolver whose
+// registered type is assignable to iface, so a handler depending on an
+// interface is satisfied by any matching concrete registration even if it
+// was never registered against that interface explicitly. It's an error
+// for more than one resolver registered directly on the same Scope to
+// satisfy iface: ranging over resolvers (a map) to pick one would make the
+// choice depend on Go's randomized map iteration order, so an ambiguous
+// interface dependency could silently resolve to a different concrete
+// type from one request to the next. resolveAssignable panics instead,
+// the same way register panics on a duplicate exact registration, and
+// points the caller at registering one of the candidates explicitly via
+// RegisterResolverAs/RegisterStaticAs.
+func (s *Scope) resolveAssignable(iface reflect.Type) (Injector, bool) {
+	s.mu.RLock()
+	var match Injector
+	ambiguous := false
+	for t, fn := range s.resolvers {
+		if t == nil || !t.AssignableTo(iface) {
+			continue
+		}
+		if match != nil {
+			ambiguous = true
+			break
+		}
+		match = fn
+	}
+	s.mu.RUnlock()
+	if ambiguous {
+		panic("injector: ambiguous resolver for interface " + iface.String() + ": more than one registered type satisfies it; register one explicitly with RegisterResolverAs/RegisterStaticAs")
+	}
+	if match != nil {
+		return match, true
+	}
+	if s.parent != nil {
+		return s.parent.resolveAssignable(iface)
+	}
+	return nil, false
+}
+
+// resolve looks up a resolver for t on this Scope, falling back to the
+// parent chain. If t is an interface with no exact registration, it also
+// falls back to any registered type that implements it.
+func (s *Scope) resolve(t reflect.Type, r *http.Request) (Injector, bool) {
+	if fn, ok := s.resolveExact(t); ok {
+		return fn, true
+	}
+	if t != nil && t.Kind() == reflect.Interface {
+		return s.resolveAssignable(t)
+	}
+	return nil, false
+}
+
+// root is the default Scope backing the package-level RegisterResolver,
+// RegisterStatic, and Inject helpers, so callers that don't build their
+// own Scope keep a single global registry to work with.
+var root = New()
+
+// RegisterResolverOn registers a function that resolves a type dynamically
+// per request against scope, rather than the package-level root Scope, so
+// a Router given its own Scope (see Router.SetScope) can carry resolvers
+// that only make sense for that Router instead of polluting the global
+// registry. Go doesn't allow a generic method like (*Scope) RegisterResolver,
+// so scope is taken as a parameter instead of a receiver.
+func RegisterResolverOn[T any](scope *Scope, fn func(*http.Request) T) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		iface := reflect.TypeOf((*T)(nil)).Elem()
+		panic("injector: RegisterResolverOn can't target interface type " + iface.String() + "; use RegisterResolverAsOn instead")
+	}
+	scope.register(t, func(r *http.Request) any {
+		return fn(r)
+	})
+}
+
+// RegisterStaticOn is the static-value counterpart to RegisterResolverOn.
+func RegisterStaticOn[T any](scope *Scope, val T) {
+	RegisterResolverOn(scope, func(_ *http.Request) T {
 		return val
 	})
 }
 
-// Inject wraps a function and builds an http.HandlerFunc with precompiled injection.
-func Inject(fn any) http.HandlerFunc {
+// RegisterResolverAsOn registers fn against the interface type Iface rather
+// than its own concrete return type, against scope rather than the
+// package-level root Scope. RegisterResolverOn can't target an interface
+// directly because reflect.TypeOf on an interface-typed zero value yields
+// the underlying concrete type (or nil for a nil interface), never the
+// interface type itself; RegisterResolverAsOn sidesteps that by taking the
+// interface type from a typed nil pointer instead, mirroring
+// inject.MapTo((*SpecialString)(nil)).
+func RegisterResolverAsOn[Iface any](scope *Scope, fn func(*http.Request) Iface) {
+	t := reflect.TypeOf((*Iface)(nil)).Elem()
+	scope.register(t, func(r *http.Request) any {
+		return fn(r)
+	})
+}
+
+// RegisterStaticAsOn is the static-value counterpart to RegisterResolverAsOn.
+func RegisterStaticAsOn[Iface any](scope *Scope, v Iface) {
+	RegisterResolverAsOn(scope, func(_ *http.Request) Iface {
+		return v
+	})
+}
+
+// RegisterResolver registers a function that resolves a type dynamically
+// per request, against the package-level root Scope.
+func RegisterResolver[T any](fn func(*http.Request) T) {
+	RegisterResolverOn(root, fn)
+}
+
+// RegisterStatic is a convenience helper to register static instances,
+// against the package-level root Scope.
+func RegisterStatic[T any](val T) {
+	RegisterStaticOn(root, val)
+}
+
+// RegisterResolverAs registers fn against the interface type Iface rather
+// than its own concrete return type, against the package-level root Scope.
+// See RegisterResolverAsOn for why Iface has to come from a typed nil
+// pointer rather than a zero value.
+func RegisterResolverAs[Iface any](fn func(*http.Request) Iface) {
+	RegisterResolverAsOn(root, fn)
+}
+
+// RegisterStaticAs is the static-value counterpart to RegisterResolverAs,
+// against the package-level root Scope.
+func RegisterStaticAs[Iface any](v Iface) {
+	RegisterStaticAsOn(root, v)
+}
+
+var requestType = reflect.TypeOf((*http.Request)(nil))
+var responseWriterType = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+var responseType = reflect.TypeOf((*Response)(nil)).Elem()
+
+// Response lets a handler's return value render itself onto the response
+// instead of being marshalled by a Renderer.
+type Response interface {
+	Render(w http.ResponseWriter, r *http.Request) error
+}
+
+// Renderer marshals a handler's non-error, non-Response return value onto
+// the response.
+type Renderer func(w http.ResponseWriter, r *http.Request, v any) error
+
+// DefaultRenderer writes v as JSON, unless the request's Accept header
+// contains text/plain anywhere, in which case it writes fmt.Sprint(v).
+// The order and q-values of the Accept entries are not considered.
+var DefaultRenderer Renderer = func(w http.ResponseWriter, r *http.Request, v any) error {
+	if strings.Contains(r.Header.Get("Accept"), "text/plain") {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, err := fmt.Fprint(w, v)
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(v)
+}
+
+// HTTPError is an error that knows which HTTP status it should map to.
+// DefaultErrorHandler checks for it before falling back to 500.
+type HTTPError interface {
+	error
+	StatusCode() int
+}
+
+// ErrorHandler handles a non-nil error returned from an injected handler.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// DefaultErrorHandler writes err's message with, if err implements
+// HTTPError, its status code; otherwise http.StatusInternalServerError.
+var DefaultErrorHandler ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	if httpErr, ok := err.(HTTPError); ok {
+		status = httpErr.StatusCode()
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// render dispatches a single non-error return value: through Render if it
+// implements Response, otherwise through the Renderer.
+func render(w http.ResponseWriter, r *http.Request, renderer Renderer, errorHandler ErrorHandler, out reflect.Value) {
+	if out.Type().Implements(responseType) {
+		resp, _ := out.Interface().(Response)
+		if resp == nil {
+			return
+		}
+		if err := resp.Render(w, r); err != nil {
+			errorHandler(w, r, err)
+		}
+		return
+	}
+	if err := renderer(w, r, out.Interface()); err != nil {
+		errorHandler(w, r, err)
+	}
+}
+
+// pathParamParsers holds custom parsers registered with RegisterPathParam,
+// keyed by the Go type they produce. Guarded by pathParamParsersMu the same
+// way Scope.resolvers is guarded, since a parser can be registered (e.g.
+// from an init in a package that's imported but not yet used) while a
+// Router from another package is already serving requests.
+var (
+	pathParamParsersMu sync.RWMutex
+	pathParamParsers   = map[reflect.Type]func(string) (any, error){}
+)
+
+// RegisterPathParam registers a parse function for path parameters of type
+// T, the same way RegisterResolver wires a type to a resolver, so custom
+// types (UUIDs, enums, ...) can be used as typed path parameters and in
+// path-tagged struct fields alongside the kinds parsePathValue knows
+// natively (strings, ints, bools, floats).
+func RegisterPathParam[T any](parse func(string) (T, error)) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	fn := func(s string) (any, error) {
+		return parse(s)
+	}
+	pathParamParsersMu.Lock()
+	pathParamParsers[t] = fn
+	pathParamParsersMu.Unlock()
+}
+
+// lookupPathParamParser returns the parser registered for t via
+// RegisterPathParam, if any.
+func lookupPathParamParser(t reflect.Type) (func(string) (any, error), bool) {
+	pathParamParsersMu.RLock()
+	defer pathParamParsersMu.RUnlock()
+	parse, ok := pathParamParsers[t]
+	return parse, ok
+}
+
+// isPathParamType reports whether t can be produced from a raw path
+// segment: either a registered RegisterPathParam type, or one of the
+// scalar kinds parsePathValue converts natively. Handler parameters of any
+// other kind (pointers, interfaces, structs without path tags) are assumed
+// to come from the Scope instead.
+func isPathParamType(t reflect.Type) bool {
+	if _, ok := lookupPathParamParser(t); ok {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// parsePathValue converts a raw path segment to t, via a registered
+// RegisterPathParam parser if one exists, otherwise via strconv.
+func parsePathValue(t reflect.Type, raw string) (any, error) {
+	if parse, ok := lookupPathParamParser(t); ok {
+		return parse(raw)
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(n).Convert(t).Interface(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(n).Convert(t).Interface(), nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(n).Convert(t).Interface(), nil
+	default:
+		return nil, fmt.Errorf("injector: no path parameter parser for type %s", t)
+	}
+}
+
+// resolvePathParam looks up name in the request's matched PathParams and
+// converts it to paramType, panicking with a descriptive message on a
+// missing or unparseable value (mirroring the "no injector for type"
+// panic used for unresolvable Scope dependencies).
+func resolvePathParam(name string, paramType reflect.Type, r *http.Request) any {
+	raw, ok := PathParamsFrom(r.Context())[name]
+	if !ok {
+		panic("injector: missing path parameter: " + name)
+	}
+	val, err := parsePathValue(paramType, raw)
+	if err != nil {
+		panic("injector: invalid path parameter " + name + ": " + err.Error())
+	}
+	return val
+}
+
+// pathStructField describes one field of a path-tagged struct parameter.
+type pathStructField struct {
+	index int
+	name  string
+	typ   reflect.Type
+}
+
+// pathStructPlan is the precompiled field plan for a handler parameter
+// that's a pointer to a struct with `path:"..."` tagged fields.
+type pathStructPlan struct {
+	structType reflect.Type
+	fields     []pathStructField
+}
+
+// compilePathStructPlan inspects a handler parameter type and, if it's a
+// pointer to a struct with at least one `path:"..."` tagged field, returns
+// the plan for populating it from the request's PathParams.
+func compilePathStructPlan(param reflect.Type) (*pathStructPlan, bool) {
+	if param.Kind() != reflect.Ptr || param.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	elem := param.Elem()
+
+	var fields []pathStructField
+	for i := 0; i < elem.NumField(); i++ {
+		f := elem.Field(i)
+		name, ok := f.Tag.Lookup("path")
+		if !ok {
+			continue
+		}
+		fields = append(fields, pathStructField{index: i, name: name, typ: f.Type})
+	}
+	if len(fields) == 0 {
+		return nil, false
+	}
+	return &pathStructPlan{structType: elem, fields: fields}, true
+}
+
+// build allocates the target struct and populates its path-tagged fields
+// from r's matched PathParams.
+func (p *pathStructPlan) build(r *http.Request) reflect.Value {
+	out := reflect.New(p.structType)
+	for _, f := range p.fields {
+		out.Elem().Field(f.index).Set(reflect.ValueOf(resolvePathParam(f.name, f.typ, r)))
+	}
+	return out
+}
+
+// injectFieldPlan describes one field of a struct populated by Apply (or
+// by Inject, for a handler parameter that's a pointer to such a struct),
+// tagged `inject:""`.
+type injectFieldPlan struct {
+	index int
+	typ   reflect.Type
+}
+
+// structPlan is the precompiled `inject` field plan for one struct type,
+// cached in structPlans so repeated requests for the same type don't pay
+// for reflecting over its fields again.
+type structPlan struct {
+	structType reflect.Type
+	fields     []injectFieldPlan
+}
+
+// structPlans caches structPlan by struct type (not pointer-to-struct), so
+// Apply and Inject share one plan per type regardless of how it's reached.
+var structPlans sync.Map // reflect.Type -> *structPlan
+
+// planFor returns the cached structPlan for t (a struct type), building
+// and storing one on first use.
+func planFor(t reflect.Type) *structPlan {
+	if p, ok := structPlans.Load(t); ok {
+		return p.(*structPlan)
+	}
+	var fields []injectFieldPlan
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if _, ok := f.Tag.Lookup("inject"); !ok {
+			continue
+		}
+		fields = append(fields, injectFieldPlan{index: i, typ: f.Type})
+	}
+	plan := &structPlan{structType: t, fields: fields}
+	actual, _ := structPlans.LoadOrStore(t, plan)
+	return actual.(*structPlan)
+}
+
+// hasInjectTags reports whether t is a pointer to a struct with at least
+// one `inject:""` tagged field.
+func hasInjectTags(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	elem := t.Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		if _, ok := elem.Field(i).Tag.Lookup("inject"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// apply fills elem's inject-tagged fields by resolving each one from the
+// Scope attached to r's context.
+func (p *structPlan) apply(elem reflect.Value, r *http.Request) error {
+	scope := ScopeFrom(r.Context())
+	for _, f := range p.fields {
+		fn, ok := scope.resolve(f.typ, r)
+		if !ok {
+			return fmt.Errorf("injector: no injector for field type: %s", f.typ)
+		}
+		elem.Field(f.index).Set(reflect.ValueOf(fn(r)))
+	}
+	return nil
+}
+
+// build allocates a new instance of p's struct type and applies it,
+// panicking on an unresolvable field (mirroring Inject's other resolution
+// failures, which panic rather than return an error).
+func (p *structPlan) build(r *http.Request) reflect.Value {
+	out := reflect.New(p.structType)
+	if err := p.apply(out.Elem(), r); err != nil {
+		panic(err.Error())
+	}
+	return out
+}
+
+// Apply walks the exported fields of target, a pointer to a struct, and
+// fills every field tagged `inject:""` by resolving it from the Scope
+// attached to r's context (see WithScope), falling back to the
+// package-level root Scope. It's the struct-based counterpart to passing
+// individual dependencies as handler parameters, useful once a handler or
+// a helper it calls needs five or more of them.
+//
+// Apply only takes a *http.Request, not an http.ResponseWriter, so an
+// `inject:""`-tagged http.ResponseWriter field resolves to the writer
+// captured when the request's Scope was built (see withRequestScope), not
+// one later wrapped by middleware further down the chain — unlike a
+// plain http.ResponseWriter handler parameter, which Inject always binds
+// to the live writer. Prefer a parameter for that case.
+func Apply(target any, r *http.Request) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("injector: Apply target must be a pointer to a struct, got %T", target)
+	}
+	return planFor(v.Elem().Type()).apply(v.Elem(), r)
+}
+
+// Option configures a single call to Inject.
+type Option func(*injectOptions)
+
+type injectOptions struct {
+	// pathParams names the route's path parameters in pattern order. A
+	// handler parameter that isn't *http.Request/http.ResponseWriter and
+	// whose type is a path-parameter type (see isPathParamType) consumes
+	// the next name from this list, in declaration order.
+	pathParams []string
+}
+
+// withPathParams is used internally by Router's method-aware routes to
+// tell Inject which path parameter names are available, in pattern order.
+func withPathParams(names []string) Option {
+	return func(o *injectOptions) {
+		o.pathParams = names
+	}
+}
+
+// Inject wraps a function and builds an http.HandlerFunc with precompiled
+// parameter resolution. Resolvers are looked up, per request, from the
+// Scope attached to the request's context (see WithScope), falling back
+// to the package-level root Scope when none is attached. A parameter
+// declared as an interface is resolved either from a resolver registered
+// against that interface (RegisterResolverAs/RegisterStaticAs) or, failing
+// that, from any registered concrete value that satisfies it.
+//
+// fn may also return values instead of writing to the ResponseWriter
+// itself: error, (T, error), a type implementing Response, or any other
+// type T to be marshalled by DefaultRenderer. A non-nil error is passed to
+// DefaultErrorHandler rather than rendered. fn with no return values
+// writes to the ResponseWriter directly, as before.
+//
+// A parameter that's a pointer to a struct with `inject:""` tagged fields
+// is allocated and populated the same way Apply does, instead of being
+// resolved as a single dependency; this is usually more ergonomic than a
+// long positional parameter list once a handler needs five or more
+// dependencies.
+func Inject(fn any, opts ...Option) http.HandlerFunc {
 	v := reflect.ValueOf(fn)
 	t := v.Type()
 
@@ -41,133 +584,195 @@ func Inject(fn any) http.HandlerFunc {
 		panic("injected: expected a function")
 	}
 
+	var cfg injectOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	// Precompile resolvers at registration time
 	resolvers := make([]func(http.ResponseWriter, *http.Request) reflect.Value, t.NumIn())
+	pathParamIndex := 0
 
 	for i := 0; i < t.NumIn(); i++ {
 		param := t.In(i)
 
 		switch param {
-		case reflect.TypeOf((*http.Request)(nil)):
+		case requestType:
 			resolvers[i] = func(_ http.ResponseWriter, r *http.Request) reflect.Value {
 				return reflect.ValueOf(r)
 			}
-		case reflect.TypeOf((*http.ResponseWriter)(nil)).Elem():
+		case responseWriterType:
 			resolvers[i] = func(w http.ResponseWriter, _ *http.Request) reflect.Value {
 				return reflect.ValueOf(w)
 			}
 		default:
-			injector, ok := injectors[param]
-			if !ok {
-				panic("no injector for type: " + param.String())
+			if plan, ok := compilePathStructPlan(param); ok {
+				resolvers[i] = func(_ http.ResponseWriter, r *http.Request) reflect.Value {
+					return plan.build(r)
+				}
+				break
+			}
+			if hasInjectTags(param) {
+				plan := planFor(param.Elem())
+				resolvers[i] = func(_ http.ResponseWriter, r *http.Request) reflect.Value {
+					return plan.build(r)
+				}
+				break
+			}
+			if isPathParamType(param) && pathParamIndex < len(cfg.pathParams) {
+				name := cfg.pathParams[pathParamIndex]
+				pathParamIndex++
+				resolvers[i] = func(_ http.ResponseWriter, r *http.Request) reflect.Value {
+					return reflect.ValueOf(resolvePathParam(name, param, r))
+				}
+				break
 			}
 			resolvers[i] = func(_ http.ResponseWriter, r *http.Request) reflect.Value {
-				return reflect.ValueOf(injector(r))
+				fn, ok := ScopeFrom(r.Context()).resolve(param, r)
+				if !ok {
+					panic("no injector for type: " + param.String())
+				}
+				return reflect.ValueOf(fn(r))
 			}
 		}
 	}
 
+	// Precompile how return values are dispatched, based on NumOut, so the
+	// hot path only has to check nil-ness of the actual results.
+	renderer := DefaultRenderer
+	errorHandler := DefaultErrorHandler
+
+	var respond func(w http.ResponseWriter, r *http.Request, out []reflect.Value)
+
+	switch t.NumOut() {
+	case 0:
+		respond = func(http.ResponseWriter, *http.Request, []reflect.Value) {}
+	case 1:
+		if t.Out(0) == errorType {
+			respond = func(w http.ResponseWriter, r *http.Request, out []reflect.Value) {
+				if err, _ := out[0].Interface().(error); err != nil {
+					errorHandler(w, r, err)
+				}
+			}
+		} else {
+			respond = func(w http.ResponseWriter, r *http.Request, out []reflect.Value) {
+				render(w, r, renderer, errorHandler, out[0])
+			}
+		}
+	case 2:
+		if t.Out(1) != errorType {
+			panic("injected: second return value must be error")
+		}
+		respond = func(w http.ResponseWriter, r *http.Request, out []reflect.Value) {
+			if err, _ := out[1].Interface().(error); err != nil {
+				errorHandler(w, r, err)
+				return
+			}
+			render(w, r, renderer, errorHandler, out[0])
+		}
+	default:
+		panic("injected: expected at most (value, error) return values")
+	}
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		args := make([]reflect.Value, len(resolvers))
 		for i, resolver := range resolvers {
 			args[i] = resolver(w, r)
 		}
-		v.Call(args)
+		respond(w, r, v.Call(args))
 	}
 }
 
-// Middleware wraps a function returning func(http.Handler) http.Handler and injects its dependencies.
-func Middleware(fn any) func(http.Handler) http.Handler {
-	v := reflect.ValueOf(fn)
+var handlerMiddlewareType = reflect.TypeOf((func(http.Handler) http.Handler)(nil))
+
+// checkMiddlewareFunc validates that fn has the shape
+// func(deps...) func(http.Handler) http.Handler and returns its precompiled
+// argument resolvers, one per dependency. *http.Request and
+// http.ResponseWriter params are bound directly to the w/r a resolver is
+// called with, the same as Inject, rather than resolved from scope — so
+// they're always the live request and the writer actually passed down the
+// middleware chain, not one captured once when the Scope was built (see
+// withRequestScope). Every other param is resolved from scope as before.
+func checkMiddlewareFunc(fn any) (v reflect.Value, resolvers []func(scope *Scope, w http.ResponseWriter, r *http.Request) reflect.Value) {
+	v = reflect.ValueOf(fn)
 	t := v.Type()
 
 	if t.Kind() != reflect.Func || t.NumOut() != 1 {
 		panic("injector: middleware must be a function returning one value")
 	}
-
-	if t.Out(0) != reflect.TypeOf((func(http.Handler) http.Handler)(nil)) {
+	if t.Out(0) != handlerMiddlewareType {
 		panic("injector: middleware must return func(http.Handler) http.Handler")
 	}
 
-	// Precompile argument resolvers
-	resolvers := make([]func(*http.Request) reflect.Value, t.NumIn())
+	resolvers = make([]func(scope *Scope, w http.ResponseWriter, r *http.Request) reflect.Value, t.NumIn())
 	for i := 0; i < t.NumIn(); i++ {
 		param := t.In(i)
-		injectorFn, ok := injectors[param]
-		if !ok {
-			panic("no injector for middleware param: " + param.String())
-		}
-		resolvers[i] = func(r *http.Request) reflect.Value {
-			return reflect.ValueOf(injectorFn(r))
-		}
-	}
-
-	return func(next http.Handler) http.Handler {
-		// Create dummy request to resolve dependencies
-		dummyReq, _ := http.NewRequest("GET", "/", nil)
-		args := make([]reflect.Value, len(resolvers))
-		for i, resolver := range resolvers {
-			args[i] = resolver(dummyReq)
+		switch param {
+		case requestType:
+			resolvers[i] = func(_ *Scope, _ http.ResponseWriter, r *http.Request) reflect.Value {
+				return reflect.ValueOf(r)
+			}
+		case responseWriterType:
+			resolvers[i] = func(_ *Scope, w http.ResponseWriter, _ *http.Request) reflect.Value {
+				if w == nil {
+					panic("injector: http.ResponseWriter middleware param needs a live request; MiddlewareFactory resolves once at registration, before any request exists — use Middleware instead")
+				}
+				return reflect.ValueOf(w)
+			}
+		default:
+			resolvers[i] = func(scope *Scope, _ http.ResponseWriter, r *http.Request) reflect.Value {
+				fn, ok := scope.resolve(param, r)
+				if !ok {
+					panic("no injector for middleware param: " + param.String())
+				}
+				return reflect.ValueOf(fn(r))
+			}
 		}
-		return v.Call(args)[0].Interface().(func(http.Handler) http.Handler)(next)
-	}
-}
-
-// Router is an http.Handler that supports dependency-injected handlers and middleware.
-type Router struct {
-	mux        *http.ServeMux
-	middleware []func(http.Handler) http.Handler
-}
-
-// NewRouter creates a new injector-aware Router.
-func NewRouter() *Router {
-	return &Router{
-		mux:        http.NewServeMux(),
-		middleware: []func(http.Handler) http.Handler{},
 	}
+	return v, resolvers
 }
 
-// Use appends a middleware to the Router.
-func (r *Router) Use(mw any) {
-	// Allow raw middleware or injector-aware middleware
-	switch fn := mw.(type) {
-	case func(http.Handler) http.Handler:
-		r.middleware = append(r.middleware, fn)
-	default:
-		r.middleware = append(r.middleware, Middleware(fn))
-	}
-}
+// Middleware wraps a function taking dependencies and returning
+// func(http.Handler) http.Handler, re-resolving those dependencies on
+// every request from the Scope attached to that request's context. This
+// is the safe default: resolvers that read request-scoped state (headers,
+// path params, an authenticated user) see the real, current request
+// rather than one built once at registration time. Use MiddlewareFactory
+// instead when fn's dependencies are genuine singletons and the per-request
+// resolution cost isn't worth paying.
+func Middleware(fn any) func(http.Handler) http.Handler {
+	v, resolvers := checkMiddlewareFunc(fn)
 
-// HandleFunc registers a handler with injection support.
-func (r *Router) HandleFunc(pattern string, handler any) {
-	var h http.Handler = Inject(handler)
-	for i := len(r.middleware) - 1; i >= 0; i-- {
-		h = r.middleware[i](h)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := ScopeFrom(r.Context())
+			args := make([]reflect.Value, len(resolvers))
+			for i, resolver := range resolvers {
+				args[i] = resolver(scope, w, r)
+			}
+			built := v.Call(args)[0].Interface().(func(http.Handler) http.Handler)
+			built(next).ServeHTTP(w, r)
+		})
 	}
-	r.mux.Handle(pattern, h)
 }
 
-// Handle registers a handler or function with injection support.
-func (r *Router) Handle(pattern string, h any) {
-	var handler http.Handler
+// MiddlewareFactory wraps a function taking dependencies and returning
+// func(http.Handler) http.Handler, resolving those dependencies once,
+// against the package-level root Scope, when the factory is applied to a
+// handler (i.e. at registration time) rather than on every request. Only
+// use this for middleware whose dependencies are root-scope singletons;
+// anything that needs to read the current request must use Middleware.
+func MiddlewareFactory(fn any) func(http.Handler) http.Handler {
+	v, resolvers := checkMiddlewareFunc(fn)
 
-	switch v := h.(type) {
-	case http.Handler:
-		handler = v
-	default:
-		handler = Inject(v)
-	}
-
-	for i := len(r.middleware) - 1; i >= 0; i-- {
-		handler = r.middleware[i](handler)
+	return func(next http.Handler) http.Handler {
+		dummyReq, _ := http.NewRequest("GET", "/", nil)
+		args := make([]reflect.Value, len(resolvers))
+		for i, resolver := range resolvers {
+			args[i] = resolver(root, nil, dummyReq)
+		}
+		return v.Call(args)[0].Interface().(func(http.Handler) http.Handler)(next)
 	}
-
-	r.mux.Handle(pattern, handler)
-}
-
-// ServeHTTP dispatches the request to the appropriate handler.
-func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.mux.ServeHTTP(w, req)
 }
 
 // Context helpers.
@@ -205,3 +810,45 @@ func Try[T any](ctx context.Context) (T, bool) {
 	}
 	return v.(T), true
 }
+
+// Resolve looks up a value of type T from the Scope attached to r's
+// context, falling back to the package-level root Scope, the same way
+// Inject resolves a handler parameter — but without panicking if T isn't
+// registered. It's the Scope-based counterpart to Try, useful for
+// middleware that wants to use a dependency (a logger, say) when the
+// caller registered one but work fine if they didn't.
+func Resolve[T any](r *http.Request) (T, bool) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		t = reflect.TypeOf((*T)(nil)).Elem()
+	}
+	fn, ok := ScopeFrom(r.Context()).resolve(t, r)
+	if !ok {
+		return zero, false
+	}
+	val, ok := fn(r).(T)
+	if !ok {
+		return zero, false
+	}
+	return val, true
+}
+
+// scopeCtxKey is the context key under which the active Scope is stored.
+type scopeCtxKey struct{}
+
+// WithScope attaches a Scope to ctx so downstream handlers and middleware
+// resolved via Inject/Use can pull additional, per-request resolutions
+// from it.
+func WithScope(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, scopeCtxKey{}, s)
+}
+
+// ScopeFrom returns the Scope attached to ctx, or the package-level root
+// Scope if none was attached.
+func ScopeFrom(ctx context.Context) *Scope {
+	if s, ok := ctx.Value(scopeCtxKey{}).(*Scope); ok {
+		return s
+	}
+	return root
+}
@@ -0,0 +1,270 @@
+package injector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type injectTagDeps struct {
+	Name   string `inject:""`
+	Ignore string
+}
+
+func TestApplyInjectTags(t *testing.T) {
+	scope := New()
+	RegisterStaticOn(scope, "alice")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(WithScope(req.Context(), scope))
+
+	var d injectTagDeps
+	if err := Apply(&d, req); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if d.Name != "alice" {
+		t.Fatalf("Name = %q, want %q", d.Name, "alice")
+	}
+	if d.Ignore != "" {
+		t.Fatalf("Ignore = %q, want empty: untagged fields must not be populated", d.Ignore)
+	}
+}
+
+func TestInjectStructPointerParam(t *testing.T) {
+	scope := New()
+	RegisterStaticOn(scope, "bob")
+
+	h := Inject(func(w http.ResponseWriter, d *injectTagDeps) {
+		w.Write([]byte(d.Name))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(WithScope(req.Context(), scope))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "bob"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+// requestedPath is resolved from the real *http.Request, so it only comes
+// out right if Middleware re-resolves its dependencies per request rather
+// than once, against a dummy request, at registration time.
+type requestedPath string
+
+func TestMiddlewareResolvesPerRequest(t *testing.T) {
+	scope := New()
+	RegisterResolverOn(scope, func(r *http.Request) requestedPath {
+		return requestedPath(r.URL.Path)
+	})
+
+	mw := Middleware(func(path requestedPath) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(path))
+			})
+		}
+	})
+	handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for _, path := range []string{"/one", "/two"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(WithScope(req.Context(), scope))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Body.String(); got != path {
+			t.Fatalf("Middleware saw path %q for request to %q, want it re-resolved per request", got, path)
+		}
+	}
+}
+
+type greeter interface {
+	Greet() string
+}
+
+type greeterA struct{}
+
+func (greeterA) Greet() string { return "A" }
+
+type greeterB struct{}
+
+func (greeterB) Greet() string { return "B" }
+
+func TestInterfaceFallbackResolvesConcreteRegistration(t *testing.T) {
+	scope := New()
+	RegisterStaticOn(scope, greeterA{})
+
+	h := Inject(func(w http.ResponseWriter, g greeter) {
+		w.Write([]byte(g.Greet()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(WithScope(req.Context(), scope))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "A"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestAmbiguousInterfaceResolutionPanics(t *testing.T) {
+	scope := New()
+	RegisterStaticOn(scope, greeterA{})
+	RegisterStaticOn(scope, greeterB{})
+
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			t.Fatal("resolve of an ambiguous interface did not panic")
+		}
+		msg, ok := rec.(string)
+		if !ok || !strings.Contains(msg, "ambiguous") {
+			t.Fatalf("panic = %v, want a message mentioning ambiguity", rec)
+		}
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(WithScope(req.Context(), scope))
+	scope.resolve(reflect.TypeOf((*greeter)(nil)).Elem(), req)
+}
+
+func TestRegisterResolverOnInterfaceTypePanics(t *testing.T) {
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			t.Fatal("RegisterResolverOn with an interface type parameter did not panic")
+		}
+		msg, ok := rec.(string)
+		if !ok || !strings.Contains(msg, "RegisterResolverAsOn") {
+			t.Fatalf("panic = %v, want a message pointing at RegisterResolverAsOn", rec)
+		}
+	}()
+
+	scope := New()
+	RegisterResolverOn(scope, func(*http.Request) greeter { return greeterA{} })
+}
+
+type greeting struct {
+	Message string `json:"message"`
+}
+
+func TestInjectRendersValueAsJSON(t *testing.T) {
+	h := Inject(func() greeting { return greeting{Message: "hi"} })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Header().Get("Content-Type"), "application/json; charset=utf-8"; got != want {
+		t.Fatalf("Content-Type = %q, want %q", got, want)
+	}
+	if got, want := strings.TrimSpace(rec.Body.String()), `{"message":"hi"}`; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestInjectRendersTextPlainWhenAccepted(t *testing.T) {
+	h := Inject(func() greeting { return greeting{Message: "hi"} })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/plain")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Header().Get("Content-Type"), "text/plain; charset=utf-8"; got != want {
+		t.Fatalf("Content-Type = %q, want %q", got, want)
+	}
+	if got, want := rec.Body.String(), "{hi}"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+type notFoundError struct{ msg string }
+
+func (e notFoundError) Error() string   { return e.msg }
+func (e notFoundError) StatusCode() int { return http.StatusNotFound }
+
+func TestInjectErrorOnlyReturnUsesDefaultErrorHandler(t *testing.T) {
+	h := Inject(func() error { return notFoundError{"no such widget"} })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if got, want := strings.TrimSpace(rec.Body.String()), "no such widget"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestInjectErrorOnlyReturnNilIsNotAnError(t *testing.T) {
+	h := Inject(func() error { return nil })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Body.Len() != 0 {
+		t.Fatalf("body = %q, want empty", rec.Body.String())
+	}
+}
+
+func TestInjectValueErrorTupleRendersValueOnNilError(t *testing.T) {
+	h := Inject(func() (greeting, error) { return greeting{Message: "hi"}, nil })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := strings.TrimSpace(rec.Body.String()), `{"message":"hi"}`; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestInjectValueErrorTupleUsesErrorHandlerOnError(t *testing.T) {
+	h := Inject(func() (greeting, error) { return greeting{}, notFoundError{"gone"} })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if got, want := strings.TrimSpace(rec.Body.String()), "gone"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+type customResponse struct{ body string }
+
+func (c customResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("X-Custom", "yes")
+	_, err := w.Write([]byte(c.body))
+	return err
+}
+
+func TestInjectRendersResponseValueDirectly(t *testing.T) {
+	h := Inject(func() Response { return customResponse{body: "rendered"} })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Header().Get("X-Custom"), "yes"; got != want {
+		t.Fatalf("X-Custom = %q, want %q: Render wasn't called, value fell through to the Renderer instead", got, want)
+	}
+	if got, want := rec.Body.String(), "rendered"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+// Package middleware provides a small set of ready-to-use, injector-aware
+// middleware: Recoverer, RequestID, and RequestLogger. Together they're the
+// recommended default stack for a Router:
+//
+//	router := injector.NewRouter()
+//	router.Use(middleware.RequestID)
+//	router.Use(middleware.Recoverer)
+//	router.Use(middleware.RequestLogger)
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/lsl/injector"
+)
+
+// Logger is the logging behavior Recoverer and RequestLogger need. Register
+// one against it (e.g. via injector.RegisterStaticAs[Logger]) to have them
+// log; without one registered, both just do their work silently.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+// Recoverer is injector-aware middleware that catches panics from the rest
+// of the chain — including Inject's own "no injector for type" panic for
+// an unresolvable dependency — logs them with a stack trace via a Logger
+// resolved from the request's Scope if one is registered, and responds
+// with a generic 500 instead of letting the panic reach net/http's own
+// recovery (which would close the connection without a response).
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if logger, ok := injector.Resolve[Logger](r); ok {
+				logger.Printf("panic: %v\n%s", rec, debug.Stack())
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lsl/injector"
+)
+
+type logFunc func(format string, args ...any)
+
+func (f logFunc) Printf(format string, args ...any) { f(format, args...) }
+
+func TestRecovererRespondsWith500AndLogsPanic(t *testing.T) {
+	scope := injector.New()
+	var logged string
+	injector.RegisterStaticAsOn[Logger](scope, logFunc(func(format string, args ...any) {
+		logged = fmt.Sprintf(format, args...)
+	}))
+
+	h := Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(injector.WithScope(req.Context(), scope))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(logged, "panic: boom") {
+		t.Fatalf("logged = %q, want it to mention the panic", logged)
+	}
+}
+
+func TestRecovererWithoutLoggerStillResponds(t *testing.T) {
+	h := Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestRecovererPassesThroughWithoutPanic(t *testing.T) {
+	h := Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "ok"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/lsl/injector"
+)
+
+// RequestIDKey is the type RequestID middleware stores the generated
+// request ID under, retrievable from a handler or downstream middleware
+// via injector.Use[RequestIDKey] (or injector.Try, if it's acceptable for
+// the value to be missing).
+type RequestIDKey string
+
+// RequestID is injector-aware middleware that generates a per-request ID,
+// attaches it to the request context under RequestIDKey, and echoes it in
+// an X-Request-ID header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := RequestIDKey(newRequestID())
+		w.Header().Set("X-Request-ID", string(id))
+		next.ServeHTTP(w, r.WithContext(injector.WithValue(r.Context(), id)))
+	})
+}
+
+// newRequestID returns a random 16-byte hex-encoded identifier.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lsl/injector"
+)
+
+func TestRequestIDSetsHeaderAndContextValue(t *testing.T) {
+	var seen RequestIDKey
+	h := RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seen = injector.Use[RequestIDKey](r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	header := rec.Header().Get("X-Request-ID")
+	if header == "" {
+		t.Fatal("X-Request-ID header not set")
+	}
+	if string(seen) != header {
+		t.Fatalf("context RequestIDKey = %q, want it to match the X-Request-ID header %q", seen, header)
+	}
+}
+
+func TestRequestIDGeneratesDistinctIDsPerRequest(t *testing.T) {
+	h := RequestID(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		ids = append(ids, rec.Header().Get("X-Request-ID"))
+	}
+
+	if ids[0] == ids[1] {
+		t.Fatalf("two requests got the same request ID: %q", ids[0])
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lsl/injector"
+)
+
+// LogFormatter formats one completed request for NewRequestLogger.
+type LogFormatter func(r *http.Request, status, bytes int, duration time.Duration) string
+
+// DefaultLogFormatter is the LogFormatter RequestLogger uses.
+var DefaultLogFormatter LogFormatter = func(r *http.Request, status, bytes int, duration time.Duration) string {
+	return fmt.Sprintf("%s %s %d %dB %s", r.Method, r.URL.Path, status, bytes, duration)
+}
+
+// responseRecorder wraps an http.ResponseWriter to capture the status code
+// and byte count written, for NewRequestLogger.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	rr.status = status
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.status = http.StatusOK
+	}
+	n, err := rr.ResponseWriter.Write(b)
+	rr.bytes += n
+	return n, err
+}
+
+// NewRequestLogger returns injector-aware middleware that logs each
+// completed request, formatted by format, via a Logger resolved from the
+// request's Scope. If format is nil, DefaultLogFormatter is used. Requests
+// pass straight through, unrecorded, when no Logger is registered.
+func NewRequestLogger(format LogFormatter) func(http.Handler) http.Handler {
+	if format == nil {
+		format = DefaultLogFormatter
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger, ok := injector.Resolve[Logger](r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			start := time.Now()
+			rr := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rr, r)
+			logger.Printf("%s", format(r, rr.status, rr.bytes, time.Since(start)))
+		})
+	}
+}
+
+// RequestLogger is NewRequestLogger(nil): the ready-to-use default,
+// pluggable straight into Router.Use without configuring a formatter.
+var RequestLogger = NewRequestLogger(nil)
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lsl/injector"
+)
+
+func TestRequestLoggerCapturesStatusAndBytes(t *testing.T) {
+	scope := injector.New()
+	var logged string
+	injector.RegisterStaticAsOn[Logger](scope, logFunc(func(format string, args ...any) {
+		logged = fmt.Sprintf(format, args...)
+	}))
+
+	h := RequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req = req.WithContext(injector.WithScope(req.Context(), scope))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	want := "GET /widgets 201 5B"
+	if got := logged; len(got) < len(want) || got[:len(want)] != want {
+		t.Fatalf("logged = %q, want it to start with %q", got, want)
+	}
+}
+
+func TestRequestLoggerUsesConfiguredFormatter(t *testing.T) {
+	scope := injector.New()
+	var logged string
+	injector.RegisterStaticAsOn[Logger](scope, logFunc(func(format string, args ...any) {
+		logged = fmt.Sprintf(format, args...)
+	}))
+
+	custom := NewRequestLogger(func(r *http.Request, status, bytes int, _ time.Duration) string {
+		return fmt.Sprintf("custom %s %d %d", r.URL.Path, status, bytes)
+	})
+	h := custom(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hi"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/custom", nil)
+	req = req.WithContext(injector.WithScope(req.Context(), scope))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := logged, "custom /custom 200 2"; got != want {
+		t.Fatalf("logged = %q, want %q", got, want)
+	}
+}
+
+func TestRequestLoggerNoOpWithoutLogger(t *testing.T) {
+	h := RequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "ok"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,305 @@
+package injector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// PathParams holds the named path parameters matched for the current
+// request, keyed by name (e.g. "id" for the pattern /users/{id}).
+type PathParams map[string]string
+
+// pathParamsCtxKey is the context key under which the matched PathParams
+// for the current request are stored.
+type pathParamsCtxKey struct{}
+
+// withPathParamValues attaches matched path parameters to ctx.
+func withPathParamValues(ctx context.Context, p PathParams) context.Context {
+	return context.WithValue(ctx, pathParamsCtxKey{}, p)
+}
+
+// PathParamsFrom returns the PathParams matched for the current route, or
+// an empty, non-nil map if the request didn't go through a matched route.
+func PathParamsFrom(ctx context.Context) PathParams {
+	if p, ok := ctx.Value(pathParamsCtxKey{}).(PathParams); ok {
+		return p
+	}
+	return PathParams{}
+}
+
+// pathSegment is one "/"-separated piece of a compiled route pattern.
+type pathSegment struct {
+	literal  string // valid when name == ""
+	name     string // path parameter name, e.g. "id" for "{id}"
+	wildcard bool   // true for a "{name...}" segment, which consumes the rest of the path
+}
+
+// compilePattern parses a pattern like "/users/{id}" or "/files/{path...}"
+// into matchable segments.
+func compilePattern(pattern string) []pathSegment {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := make([]pathSegment, 0, len(parts))
+	for _, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			inner := part[1 : len(part)-1]
+			wildcard := strings.HasSuffix(inner, "...")
+			segments = append(segments, pathSegment{
+				name:     strings.TrimSuffix(inner, "..."),
+				wildcard: wildcard,
+			})
+			continue
+		}
+		segments = append(segments, pathSegment{literal: part})
+	}
+	return segments
+}
+
+// paramNames returns the path parameter names declared in segments, in
+// pattern order, for positional matching against handler parameters.
+func paramNames(segments []pathSegment) []string {
+	var names []string
+	for _, seg := range segments {
+		if seg.name != "" {
+			names = append(names, seg.name)
+		}
+	}
+	return names
+}
+
+// match reports whether path satisfies segments, returning the named path
+// parameters on success.
+func match(segments []pathSegment, path string) (PathParams, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	params := PathParams{}
+
+	for i, seg := range segments {
+		if seg.wildcard {
+			if i >= len(parts) {
+				return nil, false
+			}
+			params[seg.name] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		if seg.name != "" {
+			params[seg.name] = parts[i]
+			continue
+		}
+		if seg.literal != parts[i] {
+			return nil, false
+		}
+	}
+	if len(parts) != len(segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// route is a single method-aware, path-parameter-aware registration.
+type route struct {
+	method   string // "" matches any method
+	segments []pathSegment
+	handler  http.Handler
+}
+
+// withRequestScope wraps h so that, on every request, it builds a child of
+// scope, pre-populated with the request and response writer, and attaches
+// it to the request's context before calling h. Baking this into each
+// registered handler (rather than doing it once in Router.ServeHTTP)
+// ensures a handler always resolves against the Scope of the Router it was
+// registered through — including a Group/Route sub-router's own child
+// Scope (see Router.clone) — regardless of which Router's ServeHTTP
+// actually received the request.
+func withRequestScope(scope *Scope, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		child := scope.Child()
+		child.set(requestType, func(req *http.Request) any { return req })
+		child.set(responseWriterType, func(*http.Request) any { return w })
+		h.ServeHTTP(w, r.WithContext(WithScope(r.Context(), child)))
+	})
+}
+
+// Router is an http.Handler that supports dependency-injected handlers and middleware.
+//
+// A Router created by Group or Route is a view onto the same underlying
+// route table and ServeMux as its parent, with its own copy of the
+// middleware stack, its own child Scope, and, for Route, a pattern prefix:
+// routes registered through it are visible on the parent (and vice versa),
+// but middleware registered through Use only wraps routes registered
+// through that same Router value, and resolvers registered (via
+// RegisterResolverOn and friends) against its Scope only apply to it and
+// any further groups nested under it.
+type Router struct {
+	mux        *http.ServeMux
+	routes     *[]*route
+	middleware []func(http.Handler) http.Handler
+	scope      *Scope
+	prefix     string
+}
+
+// NewRouter creates a new injector-aware Router backed by the package-level
+// root Scope.
+func NewRouter() *Router {
+	routes := []*route{}
+	return &Router{
+		mux:        http.NewServeMux(),
+		routes:     &routes,
+		middleware: []func(http.Handler) http.Handler{},
+		scope:      root,
+	}
+}
+
+// SetScope attaches a Scope to the Router. Requests handled by routes
+// registered through the Router afterwards resolve against a child of
+// this Scope, pre-populated with the *http.Request and http.ResponseWriter
+// for that request, so per-route or per-group resolvers can be registered
+// without touching the package-level root Scope. Each route captures the
+// Router's Scope at registration time (see withRequestScope), so call
+// SetScope before registering routes for it to take effect on all of them.
+func (r *Router) SetScope(s *Scope) {
+	r.scope = s
+}
+
+// Scope returns the Scope attached to the Router.
+func (r *Router) Scope() *Scope {
+	return r.scope
+}
+
+// Use appends a middleware to the Router.
+func (r *Router) Use(mw any) {
+	// Allow raw middleware or injector-aware middleware
+	switch fn := mw.(type) {
+	case func(http.Handler) http.Handler:
+		r.middleware = append(r.middleware, fn)
+	default:
+		r.middleware = append(r.middleware, Middleware(fn))
+	}
+}
+
+// wrap applies the Router's current middleware stack to h, innermost
+// registration first, matching HandleFunc/Handle.
+func (r *Router) wrap(h http.Handler) http.Handler {
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+	return h
+}
+
+// join prefixes pattern with the Router's accumulated Route prefix, if any.
+func (r *Router) join(pattern string) string {
+	if r.prefix == "" {
+		return pattern
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		pattern = "/" + pattern
+	}
+	return strings.TrimSuffix(r.prefix, "/") + pattern
+}
+
+// handle compiles pattern and registers handler for method ("" for any
+// method), with typed path parameters injected positionally into handler.
+func (r *Router) handle(method, pattern string, handler any) {
+	segments := compilePattern(r.join(pattern))
+	h := withRequestScope(r.scope, r.wrap(Inject(handler, withPathParams(paramNames(segments)))))
+	*r.routes = append(*r.routes, &route{method: method, segments: segments, handler: h})
+}
+
+// Get registers a handler for GET requests matching pattern.
+func (r *Router) Get(pattern string, handler any) { r.handle(http.MethodGet, pattern, handler) }
+
+// Post registers a handler for POST requests matching pattern.
+func (r *Router) Post(pattern string, handler any) { r.handle(http.MethodPost, pattern, handler) }
+
+// Put registers a handler for PUT requests matching pattern.
+func (r *Router) Put(pattern string, handler any) { r.handle(http.MethodPut, pattern, handler) }
+
+// Patch registers a handler for PATCH requests matching pattern.
+func (r *Router) Patch(pattern string, handler any) { r.handle(http.MethodPatch, pattern, handler) }
+
+// Delete registers a handler for DELETE requests matching pattern.
+func (r *Router) Delete(pattern string, handler any) {
+	r.handle(http.MethodDelete, pattern, handler)
+}
+
+// HandleFunc registers a handler with injection support, matching any
+// method, using http.ServeMux's own pattern syntax rather than the typed
+// path-parameter matching Get/Post/Put/Patch/Delete use.
+func (r *Router) HandleFunc(pattern string, handler any) {
+	r.mux.Handle(r.join(pattern), withRequestScope(r.scope, r.wrap(Inject(handler))))
+}
+
+// Handle registers a handler or function with injection support, matching
+// any method, using http.ServeMux's own pattern syntax.
+func (r *Router) Handle(pattern string, h any) {
+	var handler http.Handler
+	switch v := h.(type) {
+	case http.Handler:
+		handler = v
+	default:
+		handler = Inject(v)
+	}
+	r.mux.Handle(r.join(pattern), withRequestScope(r.scope, r.wrap(handler)))
+}
+
+// Group creates a sub-router over the same route table and ServeMux as r,
+// but with its own copy of r's current middleware stack and its own child
+// Scope, and runs fn against it. Middleware registered inside fn (via Use)
+// wraps only the routes fn registers, and resolvers registered against the
+// sub-router's Scope (see RegisterResolverOn) are only visible to it and
+// routes nested further under it, falling back to r's Scope for anything
+// else — leaving r and any of its other groups unaffected either way.
+func (r *Router) Group(fn func(*Router)) {
+	fn(r.clone(""))
+}
+
+// Route is like Group, but additionally prefixes every pattern registered
+// inside fn with prefix.
+func (r *Router) Route(prefix string, fn func(*Router)) {
+	fn(r.clone(prefix))
+}
+
+// clone returns a Router sharing r's route table and ServeMux, with a
+// child of r's Scope, an independent copy of r's middleware stack, and
+// prefix appended to r's own prefix.
+func (r *Router) clone(prefix string) *Router {
+	mw := make([]func(http.Handler) http.Handler, len(r.middleware))
+	copy(mw, r.middleware)
+	newPrefix := r.prefix
+	if prefix != "" {
+		newPrefix = r.join(prefix)
+	}
+	return &Router{
+		mux:        r.mux,
+		routes:     r.routes,
+		middleware: mw,
+		scope:      r.scope.Child(),
+		prefix:     newPrefix,
+	}
+}
+
+// ServeHTTP dispatches the request to the appropriate handler: it tries
+// the Get/Post/Put/Patch/Delete routes in registration order before
+// falling back to the HandleFunc/Handle patterns registered on the
+// ServeMux. The matched handler resolves against a child of the Scope of
+// whichever Router registered it (see withRequestScope), not necessarily
+// r's own Scope, so a route registered through a Group/Route sub-router
+// sees that sub-router's resolvers regardless of which Router's
+// ServeHTTP a given request came in through.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	for _, rt := range *r.routes {
+		if rt.method != "" && rt.method != req.Method {
+			continue
+		}
+		params, ok := match(rt.segments, req.URL.Path)
+		if !ok {
+			continue
+		}
+		rt.handler.ServeHTTP(w, req.WithContext(withPathParamValues(req.Context(), params)))
+		return
+	}
+
+	r.mux.ServeHTTP(w, req)
+}
@@ -0,0 +1,157 @@
+package injector
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		wantOK  bool
+		want    PathParams
+	}{
+		{"literal match", "/health", "/health", true, PathParams{}},
+		{"literal mismatch", "/health", "/status", false, nil},
+		{"named param", "/users/{id}", "/users/42", true, PathParams{"id": "42"}},
+		{"too few segments", "/users/{id}", "/users", false, nil},
+		{"too many segments", "/users/{id}", "/users/42/extra", false, nil},
+		{"wildcard consumes rest", "/files/{path...}", "/files/a/b/c", true, PathParams{"path": "a/b/c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := match(compilePattern(tt.pattern), tt.path)
+			if ok != tt.wantOK {
+				t.Fatalf("match() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("match() = %v, want %v", got, tt.want)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Fatalf("match()[%q] = %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParamNames(t *testing.T) {
+	got := paramNames(compilePattern("/users/{id}/posts/{postID}"))
+	want := []string{"id", "postID"}
+	if len(got) != len(want) {
+		t.Fatalf("paramNames() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("paramNames()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRouterTypedPathParams(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, id int) {
+		fmt.Fprintf(w, "user:%d", id)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "user:42"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+// customID exercises RegisterPathParam, rather than parsePathValue's native
+// scalar conversions.
+type customID int
+
+func TestRouterRegisteredPathParamType(t *testing.T) {
+	RegisterPathParam(func(s string) (customID, error) {
+		n, err := strconv.Atoi(s)
+		return customID(n), err
+	})
+
+	r := NewRouter()
+	r.Get("/widgets/{id}", func(w http.ResponseWriter, id customID) {
+		fmt.Fprintf(w, "widget:%d", id)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets/7", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "widget:7"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+// groupOnly is only ever registered against a Group's own Scope, never the
+// top-level Router's, so a handler that resolves it proves the request
+// actually saw the Group's Scope rather than the top Router's.
+type groupOnly string
+
+func TestGroupResolversReachableThroughTopRouter(t *testing.T) {
+	r := NewRouter()
+	r.Group(func(g *Router) {
+		RegisterStaticOn(g.Scope(), groupOnly("from-group"))
+		g.Get("/scoped", func(w http.ResponseWriter, v groupOnly) {
+			fmt.Fprint(w, string(v))
+		})
+	})
+
+	// Dispatched through the top-level Router, as it would be in a real
+	// server (http.ListenAndServe is only ever given the outermost Router).
+	req := httptest.NewRequest(http.MethodGet, "/scoped", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "from-group"; got != want {
+		t.Fatalf("body = %q, want %q (group-scoped resolver not reached)", got, want)
+	}
+}
+
+// wrappingResponseWriter stands in for any middleware (e.g.
+// middleware.RequestLogger's responseRecorder) that wraps the
+// http.ResponseWriter it was handed before passing it on down the chain.
+type wrappingResponseWriter struct {
+	http.ResponseWriter
+}
+
+func TestMiddlewareSeesLiveResponseWriter(t *testing.T) {
+	r := NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(&wrappingResponseWriter{w}, req)
+		})
+	})
+	r.Use(Middleware(func(w http.ResponseWriter) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+				if _, ok := w.(*wrappingResponseWriter); !ok {
+					t.Errorf("Middleware saw a %T, want the *wrappingResponseWriter installed by the earlier middleware", w)
+				}
+				next.ServeHTTP(w, req)
+			})
+		}
+	}))
+	r.Get("/", func(w http.ResponseWriter) { fmt.Fprint(w, "ok") })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got, want := rec.Body.String(), "ok"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}